@@ -0,0 +1,162 @@
+package cohere
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuthRequest carries everything an Auth implementation needs to sign a
+// request: SigV4 in particular must hash the method, URL and body into its
+// canonical request rather than just the token.
+type AuthRequest struct {
+	Method string
+	URL    string
+	Body   []byte
+	Token  string
+}
+
+// Auth builds the headers used to authenticate a request, letting Reply
+// dispatch through whatever scheme the deployment expects instead of the
+// bearer token Cohere's own API uses.
+type Auth interface {
+	Headers(req AuthRequest) map[string]string
+}
+
+// BearerAuth sends the token as a standard "Authorization: Bearer" header.
+// This is Cohere's own scheme and the default when no Auth is configured.
+type BearerAuth struct{}
+
+func (BearerAuth) Headers(req AuthRequest) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + req.Token}
+}
+
+// AzureKeyAuth sends the token as an Azure AI "api-key" header.
+type AzureKeyAuth struct{}
+
+func (AzureKeyAuth) Headers(req AuthRequest) map[string]string {
+	return map[string]string{"api-key": req.Token}
+}
+
+// SigV4Auth signs the request for AWS Bedrock using AWS Signature Version 4.
+// Token carries the secret access key; AccessKeyId, Region and Service
+// identify the caller and target.
+type SigV4Auth struct {
+	AccessKeyId string
+	Region      string
+	Service     string
+
+	// clock lets tests pin the signing time; it defaults to time.Now.
+	clock func() time.Time
+}
+
+// Headers signs req per the SigV4 canonical-request algorithm: hash the
+// method/URI/query/headers/payload into a canonical request, hash that into
+// a string to sign, then HMAC the string to sign with the derived signing
+// key. Service defaults to "bedrock".
+func (s SigV4Auth) Headers(req AuthRequest) map[string]string {
+	service := s.Service
+	if service == "" {
+		service = "bedrock"
+	}
+
+	now := time.Now
+	if s.clock != nil {
+		now = s.clock
+	}
+	t := now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	dateStamp := t.Format("20060102")
+
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil
+	}
+
+	canonicalURI := u.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalQuery := canonicalQueryString(u.Query())
+	payloadHash := sha256Hex(req.Body)
+
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-date:%s\n", u.Host, amzDate)
+	signedHeaders := "host;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, s.Region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := sigV4Key(req.Token, dateStamp, s.Region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, []byte(stringToSign)))
+
+	authorization := fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyId, scope, signedHeaders, signature,
+	)
+
+	return map[string]string{
+		"Authorization": authorization,
+		"x-amz-date":    amzDate,
+		"host":          u.Host,
+	}
+}
+
+// canonicalQueryString sorts query parameters by key (and value, for
+// repeated keys) and percent-encodes them per the SigV4 spec.
+func canonicalQueryString(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string{}, q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			// SigV4 encodes spaces as %20, not the '+' QueryEscape produces.
+			ek := strings.ReplaceAll(url.QueryEscape(k), "+", "%20")
+			ev := strings.ReplaceAll(url.QueryEscape(v), "+", "%20")
+			parts = append(parts, ek+"="+ev)
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func sigV4Key(secret, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secret), []byte(dateStamp))
+	kRegion := hmacSHA256(kDate, []byte(region))
+	kService := hmacSHA256(kRegion, []byte(service))
+	return hmacSHA256(kService, []byte("aws4_request"))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"io"
 	"net/http"
+	"time"
 )
 
 const (
@@ -26,12 +27,34 @@ const (
 )
 
 type block struct {
-	Finished  bool          `json:"is_finished"`
-	Event     string        `json:"event_type"`
-	Id        string        `json:"generation_id"`
-	Text      string        `json:"text"`
-	Reason    string        `json:"finish_reason"`
-	ToolCalls []interface{} `json:"tool_calls"`
+	Finished      bool          `json:"is_finished"`
+	Event         string        `json:"event_type"`
+	Id            string        `json:"generation_id"`
+	Text          string        `json:"text"`
+	Reason        string        `json:"finish_reason"`
+	ToolCalls     []interface{} `json:"tool_calls"`
+	SearchQueries []interface{} `json:"search_queries"`
+	SearchResults []interface{} `json:"search_results"`
+	Citations     []interface{} `json:"citations"`
+	Documents     []interface{} `json:"documents"`
+	Response      *struct {
+		Meta *responseMeta `json:"meta"`
+	} `json:"response"`
+	Meta *responseMeta `json:"meta"`
+}
+
+// tokenCounts mirrors the token-count shape Cohere nests under both
+// meta.tokens and meta.billed_units.
+type tokenCounts struct {
+	InputTokens  float64 `json:"input_tokens"`
+	OutputTokens float64 `json:"output_tokens"`
+}
+
+// responseMeta is the "meta" object Cohere attaches to both streamed
+// stream-end events and non-streaming response bodies.
+type responseMeta struct {
+	BilledUnits *tokenCounts `json:"billed_units"`
+	Tokens      *tokenCounts `json:"tokens"`
 }
 
 type Message struct {
@@ -67,6 +90,13 @@ type Chat struct {
 	topK          int
 	safety        string
 	client        *emit.Session
+	connectors    []Connector
+	documents     []Document
+	tokenizer     Tokenizer
+	baseURL       string
+	apiVersion    string
+	auth          Auth
+	retry         *RetryPolicy
 }
 
 func (c *Chat) Proxies(proxies string) {
@@ -109,6 +139,54 @@ func (c *Chat) Client(client *emit.Session) {
 	c.client = client
 }
 
+func (c *Chat) Connectors(connectors []Connector) {
+	c.connectors = connectors
+}
+
+func (c *Chat) Documents(documents []Document) {
+	c.documents = documents
+}
+
+// BaseURL points the client at a non-default deployment, such as an Azure
+// AI deployment or a self-hosted proxy/gateway.
+func (c *Chat) BaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
+// APIVersion overrides the API path segment, e.g. "v1" (default) or "v2",
+// or a full Azure-style "deployments/{name}" path.
+func (c *Chat) APIVersion(apiVersion string) {
+	c.apiVersion = apiVersion
+}
+
+// Auth overrides how requests authenticate. The default is a bearer token,
+// matching Cohere's own API.
+func (c *Chat) Auth(auth Auth) {
+	c.auth = auth
+}
+
+// Retry enables exponential backoff with full jitter on transient failures.
+// Retries only happen before the first byte of the stream is delivered to
+// the caller's channel; once a token has been emitted, errors propagate as
+// before. maxAttempts counts the initial attempt, so 1 disables retrying.
+func (c *Chat) Retry(maxAttempts int, baseDelay, maxDelay time.Duration, retryOn []int) {
+	if maxAttempts < 1 {
+		return
+	}
+
+	codes := make(map[int]bool, len(retryOn))
+	for _, code := range retryOn {
+		codes[code] = true
+	}
+
+	c.retry = &RetryPolicy{
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		retryOn:     codes,
+	}
+}
+
 func New(token string, temperature float32, model string, isChat bool) Chat {
 	return Chat{
 		token:         token,
@@ -123,48 +201,106 @@ func New(token string, temperature float32, model string, isChat bool) Chat {
 }
 
 func (c *Chat) Reply(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject) (ch chan string, err error) {
-	var pathname = "/v1/chat"
-	var response *http.Response
-	payload := c.makePayload(pMessages, system, message, c.isChat, toolObject)
+	response, err := c.request(ctx, pMessages, system, message, toolObject, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ch = make(chan string)
+	go resolve(ch, response)
+	return ch, nil
+}
+
+// request builds and issues the underlying HTTP call shared by the
+// streaming and non-streaming reply paths.
+func (c *Chat) request(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject, stream bool) (response *http.Response, err error) {
+	var endpoint = "chat"
+	payload := c.makePayload(pMessages, system, message, c.isChat, toolObject, stream)
 	if !c.isChat {
-		pathname = "/v1/generate"
+		endpoint = "generate"
 	}
+	url := c.url(endpoint)
 
-	response, err = emit.ClientBuilder(c.client).
+	builder := emit.ClientBuilder(c.client).
 		Proxies(c.proxies).
 		Context(ctx).
-		URL(baseUrl+pathname).
+		URL(url).
 		Method(http.MethodPost).
-		Header("Authorization", "Bearer "+c.token).
 		Header("Accept-Language", "en-US,en;q=0.9").
 		Header("Origin", "https://dashboard.cohere.com").
 		Header("Referer", "https://dashboard.cohere.com/").
-		JHeader().
-		Body(payload).
-		DoC(emit.Status(http.StatusOK), emit.IsSTREAM)
-	if err != nil {
-		return nil, err
+		JHeader()
+
+	for k, v := range c.authHeaders(http.MethodPost, url, payload) {
+		builder = builder.Header(k, v)
 	}
+	builder = builder.Body(payload)
 
-	ch = make(chan string)
-	go resolve(ch, response)
-	return ch, nil
+	do := func() (*http.Response, error) {
+		if stream {
+			return builder.DoC(emit.Status(http.StatusOK), emit.IsSTREAM)
+		}
+		return builder.DoC(emit.Status(http.StatusOK))
+	}
+
+	if c.retry == nil {
+		return do()
+	}
+	return c.retry.do(ctx, do)
+}
+
+// url builds the request URL from the configured base URL and API version,
+// falling back to Cohere's own "/v1/{endpoint}" layout.
+func (c *Chat) url(endpoint string) string {
+	base := c.baseURL
+	if base == "" {
+		base = baseUrl
+	}
+
+	version := c.apiVersion
+	if version == "" {
+		version = "v1"
+	}
+
+	return fmt.Sprintf("%s/%s/%s", base, version, endpoint)
 }
 
-func (c *Chat) makePayload(pMessages []Message, system, message string, isChat bool, toolObject ToolObject) (payload map[string]interface{}) {
+// authHeaders returns the headers used to authenticate the request,
+// defaulting to a bearer token to match Cohere's own API.
+func (c *Chat) authHeaders(method, url string, payload map[string]interface{}) map[string]string {
+	auth := c.auth
+	if auth == nil {
+		auth = BearerAuth{}
+	}
+
+	body, _ := json.Marshal(payload)
+	return auth.Headers(AuthRequest{
+		Method: method,
+		URL:    url,
+		Body:   body,
+		Token:  c.token,
+	})
+}
+
+func (c *Chat) makePayload(pMessages []Message, system, message string, isChat bool, toolObject ToolObject, stream bool) (payload map[string]interface{}) {
 	if c.temperature < 0 {
 		c.temperature = 0.95
 	}
 
 	if isChat {
+		connectors := make([]Connector, 0)
+		if len(c.connectors) > 0 {
+			connectors = c.connectors
+		}
+
 		payload = map[string]interface{}{
 			"chat_history":      pMessages,
-			"connectors":        make([]string, 0),
+			"connectors":        connectors,
 			"message":           message,
 			"model":             c.model,
 			"preamble":          system,
 			"prompt_truncation": "OFF",
-			"stream":            true,
+			"stream":            stream,
 			"temperature":       c.temperature,
 			"tools":             toolObject.Tools,
 			"tool_results":      toolObject.Results,
@@ -178,6 +314,10 @@ func (c *Chat) makePayload(pMessages []Message, system, message string, isChat b
 			payload["safety_mode"] = c.safety
 		}
 
+		if len(c.documents) > 0 {
+			payload["documents"] = c.documents
+		}
+
 	} else {
 		payload = map[string]interface{}{
 			"k":             c.topK,
@@ -185,7 +325,7 @@ func (c *Chat) makePayload(pMessages []Message, system, message string, isChat b
 			"max_tokens":    c.maxTokens,
 			"prompt":        message,
 			"raw_prompting": false,
-			"stream":        true,
+			"stream":        stream,
 			"temperature":   c.temperature,
 		}
 	}
@@ -265,6 +405,36 @@ func resolve(ch chan string, response *http.Response) {
 				return
 			}
 			ch <- fmt.Sprintf("tool: %s", marshal)
+			continue
+		}
+
+		if b.Event == "search-queries-generation" {
+			marshal, e := json.Marshal(b.SearchQueries)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				return
+			}
+			ch <- fmt.Sprintf("search: %s", marshal)
+			continue
+		}
+
+		if b.Event == "search-results" {
+			marshal, e := json.Marshal(b.SearchResults)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				return
+			}
+			ch <- fmt.Sprintf("search: %s", marshal)
+			continue
+		}
+
+		if b.Event == "citation-generation" {
+			marshal, e := json.Marshal(b.Citations)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				return
+			}
+			ch <- fmt.Sprintf("citation: %s", marshal)
 		}
 	}
 }
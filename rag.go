@@ -0,0 +1,14 @@
+package cohere
+
+// Connector points the chat endpoint at a Cohere connector (e.g. web-search
+// or a private data source) used to ground the response.
+type Connector struct {
+	Id                string                 `json:"id"`
+	UserAccessToken   string                 `json:"user_access_token,omitempty"`
+	ContinueOnFailure bool                   `json:"continue_on_failure,omitempty"`
+	Options           map[string]interface{} `json:"options,omitempty"`
+}
+
+// Document is an arbitrary grounding document (title/snippet/url/etc) passed
+// alongside the message for retrieval-augmented generation.
+type Document map[string]string
@@ -0,0 +1,189 @@
+package cohere
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EventType tags the kind of payload carried by an Event.
+type EventType string
+
+const (
+	EventTypeText     EventType = "text"
+	EventTypeToolCall EventType = "tool"
+	EventTypeSearch   EventType = "search"
+	EventTypeCitation EventType = "citation"
+	EventTypeDone     EventType = "done"
+	EventTypeError    EventType = "error"
+)
+
+// Event is a tagged union emitted on the channel returned by ReplyStream,
+// so callers no longer need to parse the "text: "/"tool: "/"error: "
+// prefixes emitted on the legacy channel returned by Reply.
+type Event struct {
+	Type          EventType
+	Text          string
+	ToolCalls     []interface{}
+	SearchQueries []interface{}
+	SearchResults []interface{}
+	Citations     []interface{}
+	Reason        string
+	Usage         Usage
+	Err           error
+}
+
+// Response is the fully assembled result of a non-streaming call.
+type Response struct {
+	Id        string
+	Text      string
+	Reason    string
+	ToolCalls []interface{}
+	Documents []interface{}
+	Citations []interface{}
+	Usage     Usage
+}
+
+// generateResponse is the body shape of a non-streaming /v1/generate call,
+// which nests the completion inside a generations[] array rather than at
+// the top level like /v1/chat does.
+type generateResponse struct {
+	Generations []struct {
+		Id     string `json:"id"`
+		Text   string `json:"text"`
+		Reason string `json:"finish_reason"`
+	} `json:"generations"`
+	Meta *responseMeta `json:"meta"`
+}
+
+// ReplyOnce calls the Cohere endpoint with stream disabled and returns the
+// fully assembled response instead of a channel of incremental tokens.
+func (c *Chat) ReplyOnce(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject) (resp Response, err error) {
+	response, err := c.request(ctx, pMessages, system, message, toolObject, false)
+	if err != nil {
+		return Response{}, err
+	}
+	defer response.Body.Close()
+
+	if !c.isChat {
+		var g generateResponse
+		if err = json.NewDecoder(response.Body).Decode(&g); err != nil {
+			return Response{}, err
+		}
+		if len(g.Generations) == 0 {
+			return Response{}, fmt.Errorf("cohere: generate response had no generations")
+		}
+
+		gen := g.Generations[0]
+		return Response{
+			Id:     gen.Id,
+			Text:   gen.Text,
+			Reason: gen.Reason,
+			Usage:  usageFromMeta(gen.Reason, g.Meta),
+		}, nil
+	}
+
+	var b block
+	if err = json.NewDecoder(response.Body).Decode(&b); err != nil {
+		return Response{}, err
+	}
+
+	return Response{
+		Id:        b.Id,
+		Text:      b.Text,
+		Reason:    b.Reason,
+		ToolCalls: b.ToolCalls,
+		Documents: b.Documents,
+		Citations: b.Citations,
+		Usage:     usageFromBlock(b),
+	}, nil
+}
+
+// ReplyStream mirrors Reply but returns a typed Event channel rather than a
+// channel of prefixed strings, coexisting with Reply for backward
+// compatibility.
+func (c *Chat) ReplyStream(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject) (ch chan Event, err error) {
+	response, err := c.request(ctx, pMessages, system, message, toolObject, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ch = make(chan Event)
+	go resolveEvents(ch, response)
+	return ch, nil
+}
+
+func resolveEvents(ch chan Event, response *http.Response) {
+	defer close(ch)
+	defer response.Body.Close()
+
+	buf := new(bytes.Buffer)
+	r := bufio.NewReader(response.Body)
+	for {
+		line, prefix, err := r.ReadLine()
+		buf.Write(line)
+
+		if err != nil {
+			if err != io.EOF {
+				ch <- Event{Type: EventTypeError, Err: err}
+			}
+			if str := buf.String(); len(str) > 0 {
+				ch <- Event{Type: EventTypeText, Text: str}
+			}
+			return
+		}
+		if prefix {
+			continue
+		}
+
+		logrus.Tracef("--------- ORIGINAL MESSAGE ---------")
+		logrus.Tracef("%s", buf.Bytes())
+
+		var b block
+		if err = json.Unmarshal(buf.Bytes(), &b); err != nil {
+			ch <- Event{Type: EventTypeError, Err: err}
+			return
+		}
+		buf.Reset()
+
+		if b.Event == "stream-end" {
+			ch <- Event{Type: EventTypeDone, Reason: b.Reason, Usage: usageFromBlock(b)}
+			return
+		}
+
+		if b.Finished {
+			return
+		}
+
+		if b.Event == "text-generation" {
+			ch <- Event{Type: EventTypeText, Text: b.Text}
+			continue
+		}
+
+		if b.Event == "tool-calls-generation" {
+			ch <- Event{Type: EventTypeToolCall, ToolCalls: b.ToolCalls}
+			continue
+		}
+
+		if b.Event == "search-queries-generation" {
+			ch <- Event{Type: EventTypeSearch, SearchQueries: b.SearchQueries}
+			continue
+		}
+
+		if b.Event == "search-results" {
+			ch <- Event{Type: EventTypeSearch, SearchResults: b.SearchResults}
+			continue
+		}
+
+		if b.Event == "citation-generation" {
+			ch <- Event{Type: EventTypeCitation, Citations: b.Citations}
+			continue
+		}
+	}
+}
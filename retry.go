@@ -0,0 +1,123 @@
+package cohere
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures exponential backoff with full jitter for the
+// underlying HTTP call made by request. It is only installed via Retry.
+type RetryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	retryOn     map[int]bool
+}
+
+// statusCoder is implemented by error types that carry the HTTP status code
+// of a failed response, used as a fallback when attempt() didn't also
+// return the *http.Response itself (e.g. a network-level failure).
+type statusCoder interface {
+	StatusCode() int
+}
+
+// responder is implemented by error types that carry the *http.Response of
+// a failed request, used as a fallback to honor a Retry-After header on
+// 429s when attempt() didn't also return the response itself.
+type responder interface {
+	Response() *http.Response
+}
+
+func (p *RetryPolicy) do(ctx context.Context, attempt func() (*http.Response, error)) (*http.Response, error) {
+	var lastErr error
+	for i := 0; i < p.maxAttempts; i++ {
+		resp, err := attempt()
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		if i == p.maxAttempts-1 || !p.retryable(resp, err) {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, err
+		}
+
+		delay := p.backoff(i)
+		if after := retryAfter(resp, err); after > 0 {
+			delay = after
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return nil, lastErr
+}
+
+// retryable decides whether a failed attempt should be retried. A non-nil
+// resp is trusted first, since emit.io's status matcher still returns the
+// response alongside its error; the duck-typed interfaces only cover the
+// case where attempt() surfaced an error with no response at all.
+func (p *RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if resp != nil {
+		return p.retryOn[resp.StatusCode]
+	}
+
+	if sc, ok := err.(statusCoder); ok {
+		return p.retryOn[sc.StatusCode()]
+	}
+
+	type temporary interface {
+		Temporary() bool
+	}
+	if t, ok := err.(temporary); ok {
+		return t.Temporary()
+	}
+	return false
+}
+
+// backoff implements full-jitter exponential backoff:
+// sleep = rand(0, min(maxDelay, baseDelay*2^attempt)).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.baseDelay << uint(attempt)
+	if ceiling <= 0 || ceiling > p.maxDelay {
+		ceiling = p.maxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// retryAfter reads the Retry-After header off resp, falling back to the
+// duck-typed responder interface when attempt() didn't return a response.
+func retryAfter(resp *http.Response, err error) time.Duration {
+	if resp == nil {
+		if r, ok := err.(responder); ok {
+			resp = r.Response()
+		}
+	}
+	if resp == nil {
+		return 0
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0
+	}
+
+	if seconds, parseErr := strconv.Atoi(header); parseErr == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
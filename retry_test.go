@@ -0,0 +1,128 @@
+package cohere
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRetryResponse(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(""))}
+}
+
+func TestRetryPolicyRetriesOnConfiguredStatus(t *testing.T) {
+	p := &RetryPolicy{
+		maxAttempts: 3,
+		baseDelay:   time.Millisecond,
+		maxDelay:    time.Millisecond,
+		retryOn:     map[int]bool{500: true},
+	}
+
+	attempts := 0
+	resp, err := p.do(context.Background(), func() (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return newRetryResponse(500, nil), errStatus{500}
+		}
+		return newRetryResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryPolicyDoesNotRetryUnconfiguredStatus(t *testing.T) {
+	p := &RetryPolicy{
+		maxAttempts: 3,
+		baseDelay:   time.Millisecond,
+		maxDelay:    time.Millisecond,
+		retryOn:     map[int]bool{500: true},
+	}
+
+	attempts := 0
+	_, err := p.do(context.Background(), func() (*http.Response, error) {
+		attempts++
+		return newRetryResponse(http.StatusBadRequest, nil), errStatus{http.StatusBadRequest}
+	})
+	if err == nil {
+		t.Fatal("expected error for an unconfigured status")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retries, got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicyHonorsRetryAfterOn429(t *testing.T) {
+	p := &RetryPolicy{
+		maxAttempts: 2,
+		baseDelay:   time.Hour,
+		maxDelay:    time.Hour,
+		retryOn:     map[int]bool{http.StatusTooManyRequests: true},
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", "1")
+
+	attempts := 0
+	start := time.Now()
+	_, err := p.do(context.Background(), func() (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return newRetryResponse(http.StatusTooManyRequests, header), errStatus{http.StatusTooManyRequests}
+		}
+		return newRetryResponse(http.StatusOK, nil), nil
+	})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if elapsed >= time.Minute {
+		t.Fatalf("Retry-After: 1s should short-circuit the hour-long backoff ceiling, took %s", elapsed)
+	}
+}
+
+func TestRetryPolicyFallsBackToStatusCoderWithoutResponse(t *testing.T) {
+	p := &RetryPolicy{
+		maxAttempts: 2,
+		baseDelay:   time.Millisecond,
+		maxDelay:    time.Millisecond,
+		retryOn:     map[int]bool{503: true},
+	}
+
+	attempts := 0
+	_, err := p.do(context.Background(), func() (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errStatus{503}
+		}
+		return newRetryResponse(http.StatusOK, nil), nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// errStatus is a minimal statusCoder, standing in for whatever concrete
+// error type emit.io returns when its status matcher rejects a response.
+type errStatus struct{ code int }
+
+func (e errStatus) Error() string   { return "unexpected status" }
+func (e errStatus) StatusCode() int { return e.code }
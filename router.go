@@ -0,0 +1,180 @@
+package cohere
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Strategy selects the order in which a Router attempts its members.
+type Strategy int
+
+const (
+	Priority Strategy = iota
+	RoundRobin
+	LeastLatency
+	WeightedRandom
+)
+
+// member wraps a Chat with routing metadata: a weight for WeightedRandom and
+// health tracking used to cool down models that are returning errors.
+type member struct {
+	chat   *Chat
+	weight int
+
+	mu           sync.Mutex
+	consecutive  int
+	cooldownTill time.Time
+	avgLatency   time.Duration
+}
+
+func (m *member) healthy(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return now.After(m.cooldownTill)
+}
+
+func (m *member) latency() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.avgLatency
+}
+
+func (m *member) recordSuccess(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consecutive = 0
+	m.cooldownTill = time.Time{}
+	if m.avgLatency == 0 {
+		m.avgLatency = latency
+	} else {
+		m.avgLatency = (m.avgLatency + latency) / 2
+	}
+}
+
+func (m *member) recordFailure(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consecutive++
+
+	backoff := time.Duration(1<<uint(m.consecutive-1)) * time.Second
+	if backoff > 2*time.Minute {
+		backoff = 2 * time.Minute
+	}
+	m.cooldownTill = now.Add(backoff)
+}
+
+// Router wraps multiple Chat instances, potentially against different base
+// URLs/models/tokens, and fails over between them according to Strategy
+// before any tokens have been delivered to the caller.
+type Router struct {
+	strategy Strategy
+	members  []*member
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRouter builds a Router over the given Chat instances using strategy to
+// pick the attempt order.
+func NewRouter(strategy Strategy, chats ...*Chat) *Router {
+	members := make([]*member, 0, len(chats))
+	for _, c := range chats {
+		members = append(members, &member{chat: c, weight: 1})
+	}
+	return &Router{strategy: strategy, members: members}
+}
+
+// Weight sets the WeightedRandom weight for the i-th member added via
+// NewRouter. Weights below 1 are ignored.
+func (r *Router) Weight(i, weight int) {
+	if i < 0 || i >= len(r.members) || weight < 1 {
+		return
+	}
+	r.members[i].weight = weight
+}
+
+// order returns the attempt order for this call according to the
+// configured strategy, skipping members still in their cool-down window.
+func (r *Router) order(now time.Time) []*member {
+	candidates := make([]*member, 0, len(r.members))
+	for _, m := range r.members {
+		if m.healthy(now) {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		// Every member is cooling down; probe them all, in the configured
+		// strategy's order, rather than failing outright.
+		candidates = append(candidates, r.members...)
+	}
+
+	switch r.strategy {
+	case RoundRobin:
+		r.mu.Lock()
+		start := r.next % len(candidates)
+		r.next++
+		r.mu.Unlock()
+		return append(candidates[start:], candidates[:start]...)
+
+	case LeastLatency:
+		ordered := make([]*member, len(candidates))
+		copy(ordered, candidates)
+		latencies := make([]time.Duration, len(ordered))
+		for i, m := range ordered {
+			latencies[i] = m.latency()
+		}
+		for i := 1; i < len(ordered); i++ {
+			for j := i; j > 0 && latencies[j] < latencies[j-1]; j-- {
+				ordered[j], ordered[j-1] = ordered[j-1], ordered[j]
+				latencies[j], latencies[j-1] = latencies[j-1], latencies[j]
+			}
+		}
+		return ordered
+
+	case WeightedRandom:
+		total := 0
+		for _, m := range candidates {
+			total += m.weight
+		}
+		ordered := make([]*member, 0, len(candidates))
+		remaining := append([]*member{}, candidates...)
+		for len(remaining) > 0 {
+			pick := rand.Intn(total)
+			idx := 0
+			for acc := remaining[0].weight; acc <= pick && idx < len(remaining)-1; idx++ {
+				acc += remaining[idx+1].weight
+			}
+			ordered = append(ordered, remaining[idx])
+			total -= remaining[idx].weight
+			remaining = append(remaining[:idx], remaining[idx+1:]...)
+		}
+		return ordered
+
+	default: // Priority
+		return candidates
+	}
+}
+
+// Reply attempts each member in strategy order, streaming from the first one
+// that accepts the request, and transparently failing over to the next
+// member on connection errors or a non-200 status before any tokens have
+// been delivered.
+func (r *Router) Reply(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject) (ch chan string, err error) {
+	var lastErr error
+	now := time.Now()
+	for _, m := range r.order(now) {
+		started := time.Now()
+		ch, err = m.chat.Reply(ctx, pMessages, system, message, toolObject)
+		if err != nil {
+			m.recordFailure(time.Now())
+			lastErr = err
+			continue
+		}
+		m.recordSuccess(time.Since(started))
+		return ch, nil
+	}
+	return nil, fmt.Errorf("router: all members exhausted, last error: %v", lastErr)
+}
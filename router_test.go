@@ -0,0 +1,90 @@
+package cohere
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestMember() *member {
+	return &member{chat: &Chat{}, weight: 1}
+}
+
+func TestRouterOrderPriority(t *testing.T) {
+	r := &Router{strategy: Priority, members: []*member{newTestMember(), newTestMember(), newTestMember()}}
+	ordered := r.order(time.Now())
+	if len(ordered) != 3 {
+		t.Fatalf("expected 3 candidates, got %d", len(ordered))
+	}
+	for i, m := range ordered {
+		if m != r.members[i] {
+			t.Fatalf("priority strategy reordered members: index %d", i)
+		}
+	}
+}
+
+func TestRouterOrderRoundRobin(t *testing.T) {
+	r := &Router{strategy: RoundRobin, members: []*member{newTestMember(), newTestMember(), newTestMember()}}
+
+	first := r.order(time.Now())
+	second := r.order(time.Now())
+	if first[0] == second[0] {
+		t.Fatalf("round robin did not advance between calls")
+	}
+}
+
+func TestRouterOrderSkipsCoolingDownMembers(t *testing.T) {
+	now := time.Now()
+	cold := newTestMember()
+	cold.recordFailure(now)
+
+	r := &Router{strategy: Priority, members: []*member{cold, newTestMember()}}
+	ordered := r.order(now)
+	if len(ordered) != 1 || ordered[0] == cold {
+		t.Fatalf("expected the cooling-down member to be skipped, got %d candidates", len(ordered))
+	}
+}
+
+func TestRouterOrderWeightedRandomIncludesAllMembers(t *testing.T) {
+	r := &Router{strategy: WeightedRandom, members: []*member{newTestMember(), newTestMember(), newTestMember()}}
+
+	seen := map[*member]bool{}
+	for i := 0; i < 200; i++ {
+		ordered := r.order(time.Now())
+		if len(ordered) != len(r.members) {
+			t.Fatalf("expected %d candidates, got %d", len(r.members), len(ordered))
+		}
+		for _, m := range ordered {
+			seen[m] = true
+		}
+		if len(seen) == len(r.members) {
+			return
+		}
+	}
+	t.Fatalf("weighted random never produced all %d members across 200 samples", len(r.members))
+}
+
+func TestRouterOrderLeastLatencyIsRaceFree(t *testing.T) {
+	r := &Router{strategy: LeastLatency, members: []*member{newTestMember(), newTestMember(), newTestMember()}}
+
+	var wg sync.WaitGroup
+	for _, m := range r.members {
+		wg.Add(1)
+		go func(m *member) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.recordSuccess(time.Duration(i) * time.Millisecond)
+			}
+		}(m)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			r.order(time.Now())
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,206 @@
+package cohere
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Usage reports token accounting for a single call, taken from Cohere's
+// stream-end response metadata.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+	BilledUnits  int
+	Reason       string
+}
+
+// UsageFuture resolves to the Usage of a call once its stream has closed.
+type UsageFuture struct {
+	ch chan Usage
+}
+
+// Wait blocks until the stream closes and returns its final Usage.
+func (f *UsageFuture) Wait() Usage {
+	return <-f.ch
+}
+
+// Tokenizer counts the tokens in a piece of text. ReplyWithUsage adds up its
+// count of each text-generation delta as the stream runs and reports that
+// total as OutputTokens when the stream ends without a stream-end token
+// count. The running total is not exposed to callers while the stream is
+// still open; it is only available from UsageFuture.Wait.
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// whitespaceTokenizer is the default Tokenizer: it approximates a token
+// count by splitting on whitespace.
+type whitespaceTokenizer struct{}
+
+func (whitespaceTokenizer) Count(text string) int {
+	return len(strings.Fields(text))
+}
+
+// Tokenizer overrides the Tokenizer used for incremental output-token
+// counting. The default is a whitespace approximation.
+func (c *Chat) Tokenizer(tokenizer Tokenizer) {
+	c.tokenizer = tokenizer
+}
+
+// usageFromBlock extracts Usage from a block's metadata. Streamed
+// stream-end events nest it under "response", while a non-streaming
+// /v1/chat body carries it at the top level, so both are checked.
+func usageFromBlock(b block) Usage {
+	if b.Response != nil {
+		return usageFromMeta(b.Reason, b.Response.Meta)
+	}
+	return usageFromMeta(b.Reason, b.Meta)
+}
+
+// usageFromMeta builds a Usage from a response's "meta" object, shared by
+// both the streaming stream-end event and the non-streaming response body.
+func usageFromMeta(reason string, meta *responseMeta) Usage {
+	u := Usage{Reason: reason}
+	if meta == nil {
+		return u
+	}
+	if t := meta.Tokens; t != nil {
+		u.InputTokens = int(t.InputTokens)
+		u.OutputTokens = int(t.OutputTokens)
+	}
+	if bu := meta.BilledUnits; bu != nil {
+		u.BilledUnits = int(bu.InputTokens + bu.OutputTokens)
+	}
+	return u
+}
+
+// ReplyWithUsage mirrors Reply but additionally returns an UsageFuture that
+// resolves once the stream closes, so callers can enforce budgets and emit
+// metrics without parsing the channel themselves.
+func (c *Chat) ReplyWithUsage(ctx context.Context, pMessages []Message, system, message string, toolObject ToolObject) (ch chan string, usage *UsageFuture, err error) {
+	response, err := c.request(ctx, pMessages, system, message, toolObject, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenizer := c.tokenizer
+	if tokenizer == nil {
+		tokenizer = whitespaceTokenizer{}
+	}
+
+	ch = make(chan string)
+	usage = &UsageFuture{ch: make(chan Usage, 1)}
+	go resolveWithUsage(ch, usage.ch, response, tokenizer)
+	return ch, usage, nil
+}
+
+func resolveWithUsage(ch chan string, usageCh chan Usage, response *http.Response, tokenizer Tokenizer) {
+	defer close(ch)
+	defer close(usageCh)
+	defer response.Body.Close()
+
+	outputTokens := 0
+	buf := new(bytes.Buffer)
+	r := bufio.NewReader(response.Body)
+	for {
+		line, prefix, err := r.ReadLine()
+		buf.Write(line)
+
+		if err != nil {
+			if err != io.EOF {
+				ch <- fmt.Sprintf("error: %v", err)
+			}
+			if str := buf.String(); len(str) > 0 {
+				ch <- "text: " + str
+			}
+			usageCh <- Usage{OutputTokens: outputTokens}
+			return
+		}
+		if prefix {
+			continue
+		}
+
+		logrus.Tracef("--------- ORIGINAL MESSAGE ---------")
+		logrus.Tracef("%s", buf.Bytes())
+
+		var b block
+		if err = json.Unmarshal(buf.Bytes(), &b); err != nil {
+			ch <- fmt.Sprintf("error: %v", err)
+			usageCh <- Usage{OutputTokens: outputTokens}
+			return
+		}
+		buf.Reset()
+
+		if b.Event == "stream-end" {
+			u := usageFromBlock(b)
+			if u.OutputTokens == 0 {
+				u.OutputTokens = outputTokens
+			}
+			usageCh <- u
+			return
+		}
+
+		if b.Finished {
+			usageCh <- Usage{OutputTokens: outputTokens}
+			return
+		}
+
+		if b.Event == "text-generation" {
+			outputTokens += tokenizer.Count(b.Text)
+			ch <- "text: " + b.Text
+			continue
+		}
+
+		if b.Event == "tool-calls-generation" {
+			marshal, e := json.Marshal(b.ToolCalls)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				usageCh <- Usage{OutputTokens: outputTokens}
+				return
+			}
+			ch <- fmt.Sprintf("tool: %s", marshal)
+			continue
+		}
+
+		if b.Event == "search-queries-generation" {
+			marshal, e := json.Marshal(b.SearchQueries)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				usageCh <- Usage{OutputTokens: outputTokens}
+				return
+			}
+			ch <- fmt.Sprintf("search: %s", marshal)
+			continue
+		}
+
+		if b.Event == "search-results" {
+			marshal, e := json.Marshal(b.SearchResults)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				usageCh <- Usage{OutputTokens: outputTokens}
+				return
+			}
+			ch <- fmt.Sprintf("search: %s", marshal)
+			continue
+		}
+
+		if b.Event == "citation-generation" {
+			marshal, e := json.Marshal(b.Citations)
+			if e != nil {
+				ch <- fmt.Sprintf("error: %v", e)
+				usageCh <- Usage{OutputTokens: outputTokens}
+				return
+			}
+			ch <- fmt.Sprintf("citation: %s", marshal)
+			continue
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cohere
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestUsageFromBlockTopLevelMeta covers a realistic non-streaming /v1/chat
+// response body, where "meta" sits at the top level rather than nested
+// under "response" like the streamed stream-end event.
+func TestUsageFromBlockTopLevelMeta(t *testing.T) {
+	body := []byte(`{
+		"response_id": "abcd",
+		"text": "hello there",
+		"generation_id": "gen-1",
+		"finish_reason": "COMPLETE",
+		"meta": {
+			"tokens": {"input_tokens": 12, "output_tokens": 34},
+			"billed_units": {"input_tokens": 10, "output_tokens": 30}
+		}
+	}`)
+
+	var b block
+	if err := json.Unmarshal(body, &b); err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+
+	usage := usageFromBlock(b)
+	if usage.InputTokens != 12 || usage.OutputTokens != 34 {
+		t.Fatalf("expected tokens 12/34, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+	if usage.BilledUnits != 40 {
+		t.Fatalf("expected billed units 40, got %d", usage.BilledUnits)
+	}
+	if usage.Reason != "COMPLETE" {
+		t.Fatalf("expected reason COMPLETE, got %q", usage.Reason)
+	}
+}
+
+// TestUsageFromBlockStreamedMeta covers the streamed stream-end shape,
+// where meta nests under "response".
+func TestUsageFromBlockStreamedMeta(t *testing.T) {
+	body := []byte(`{
+		"is_finished": true,
+		"event_type": "stream-end",
+		"finish_reason": "COMPLETE",
+		"response": {
+			"meta": {
+				"tokens": {"input_tokens": 5, "output_tokens": 7}
+			}
+		}
+	}`)
+
+	var b block
+	if err := json.Unmarshal(body, &b); err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+
+	usage := usageFromBlock(b)
+	if usage.InputTokens != 5 || usage.OutputTokens != 7 {
+		t.Fatalf("expected tokens 5/7, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+}